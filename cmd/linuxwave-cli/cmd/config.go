@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vinisadev/linuxwave/internal/config"
+	"github.com/vinisadev/linuxwave/internal/output"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect and modify the linuxwave configuration",
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the effective merged configuration",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := loadConfig()
+		return output.Write(os.Stdout, currentFormat(), cfg, printConfig)
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the effective configuration and exit non-zero if invalid",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadWithOverlay(os.Environ(), nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid configuration: %v\n", err)
+			os.Exit(1)
+		}
+		return output.Write(os.Stdout, currentFormat(), cfg, func(w io.Writer, _ interface{}) error {
+			_, err := fmt.Fprintln(w, "Configuration is valid.")
+			return err
+		})
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a single configuration key and validate the result",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, value := args[0], args[1]
+
+		// Validate against the effective configuration (defaults + system
+		// file + user file + env), so a key that only becomes
+		// invalid in combination with another layer is still caught.
+		effective := loadConfig()
+		if err := config.SetByKey(effective, key, value); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := effective.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid configuration after setting %s: %v\n", key, err)
+			os.Exit(1)
+		}
+
+		// Persist only the user file's own layer plus this one key, so
+		// defaults and any transient LINUXWAVE_* env overlay folded into
+		// effective above never get written back as if the user had set
+		// them.
+		userCfg, present, err := config.LoadUserLayer()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := config.SetByKey(userCfg, key, value); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		present[key] = true
+
+		userConfigPath, err := config.UserConfigPath()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to resolve user config path: %v\n", err)
+			os.Exit(1)
+		}
+		if err := config.SaveUserLayer(userConfigPath, userCfg, present); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to save configuration: %v\n", err)
+			os.Exit(1)
+		}
+
+		return output.Write(os.Stdout, currentFormat(), effective, printConfig)
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configSetCmd)
+}
+
+func printConfig(w io.Writer, v interface{}) error {
+	cfg := v.(*config.Config)
+	_, err := fmt.Fprintln(w, cfg.String())
+	return err
+}
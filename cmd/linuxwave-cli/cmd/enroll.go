@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vinisadev/linuxwave/internal/output"
+)
+
+// enrollResult is the service's reply to an "enroll" command.
+type enrollResult struct {
+	Success bool   `json:"success" yaml:"success"`
+	Message string `json:"message" yaml:"message"`
+}
+
+var enrollCmd = &cobra.Command{
+	Use:   "enroll",
+	Short: "Enroll a new face profile for the current user",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := loadConfig()
+
+		client, err := dialService(cfg)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		var result enrollResult
+		if err := client.Call("enroll", nil, &result); err != nil {
+			return fmt.Errorf("enroll failed: %w", err)
+		}
+
+		if err := output.Write(os.Stdout, currentFormat(), result, printEnrollResult); err != nil {
+			return err
+		}
+
+		if !result.Success {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+func printEnrollResult(w io.Writer, v interface{}) error {
+	r := v.(enrollResult)
+	if r.Success {
+		_, err := fmt.Fprintf(w, "Enrollment succeeded: %s\n", r.Message)
+		return err
+	}
+	_, err := fmt.Fprintf(w, "Enrollment failed: %s\n", r.Message)
+	return err
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vinisadev/linuxwave/internal/events"
+	"github.com/vinisadev/linuxwave/internal/output"
+)
+
+var eventsFollow bool
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Show recent authentication events",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := loadConfig()
+
+		client, err := dialService(cfg)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		respCh, err := client.Stream("events", map[string]string{"follow": strconv.FormatBool(eventsFollow)})
+		if err != nil {
+			return fmt.Errorf("events failed: %w", err)
+		}
+
+		format := currentFormat()
+
+		// The first response carries the buffered backlog; every response
+		// after that, sent only when --follow is set, carries one live event.
+		first := true
+		for resp := range respCh {
+			if !resp.OK {
+				return fmt.Errorf("events failed: %s", resp.Error)
+			}
+
+			if first {
+				first = false
+				var backlog []events.Event
+				if err := json.Unmarshal(resp.Data, &backlog); err != nil {
+					return fmt.Errorf("failed to decode events backlog: %w", err)
+				}
+				if err := output.Write(os.Stdout, format, backlog, printEventList); err != nil {
+					return err
+				}
+				continue
+			}
+
+			var e events.Event
+			if err := json.Unmarshal(resp.Data, &e); err != nil {
+				return fmt.Errorf("failed to decode event: %w", err)
+			}
+			if err := output.Write(os.Stdout, format, e, printSingleEvent); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	eventsCmd.Flags().BoolVar(&eventsFollow, "follow", false, "keep streaming new events as they happen")
+	rootCmd.AddCommand(eventsCmd)
+}
+
+func printEventList(w io.Writer, v interface{}) error {
+	for _, e := range v.([]events.Event) {
+		if err := printSingleEvent(w, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printSingleEvent(w io.Writer, v interface{}) error {
+	e := v.(events.Event)
+	_, err := fmt.Fprintf(w, "[%s] %s %s\n", e.Timestamp.Format("2006-01-02T15:04:05Z07:00"), e.Type, e.Message)
+	return err
+}
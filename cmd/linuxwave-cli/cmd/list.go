@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vinisadev/linuxwave/internal/output"
+)
+
+// enrolledProfile describes one enrolled face profile as reported by the
+// service.
+type enrolledProfile struct {
+	Name       string    `json:"name" yaml:"name"`
+	EnrolledAt time.Time `json:"enrolled_at" yaml:"enrolled_at"`
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List enrolled face profiles",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := loadConfig()
+
+		client, err := dialService(cfg)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		var profiles []enrolledProfile
+		if err := client.Call("list", nil, &profiles); err != nil {
+			return fmt.Errorf("list failed: %w", err)
+		}
+
+		return output.Write(os.Stdout, currentFormat(), profiles, printProfileList)
+	},
+}
+
+func printProfileList(w io.Writer, v interface{}) error {
+	profiles := v.([]enrolledProfile)
+	if len(profiles) == 0 {
+		_, err := fmt.Fprintln(w, "No enrolled face profiles.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tENROLLED AT")
+	for _, p := range profiles {
+		fmt.Fprintf(tw, "%s\t%s\n", p.Name, p.EnrolledAt.Format(time.RFC3339))
+	}
+	return tw.Flush()
+}
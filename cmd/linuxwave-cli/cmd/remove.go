@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vinisadev/linuxwave/internal/output"
+)
+
+type removeResult struct {
+	Removed bool   `json:"removed" yaml:"removed"`
+	Name    string `json:"name" yaml:"name"`
+}
+
+var removeCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove an enrolled face profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := loadConfig()
+
+		client, err := dialService(cfg)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		var result removeResult
+		if err := client.Call("remove", map[string]string{"name": args[0]}, &result); err != nil {
+			return fmt.Errorf("remove failed: %w", err)
+		}
+
+		if err := output.Write(os.Stdout, currentFormat(), result, printRemoveResult); err != nil {
+			return err
+		}
+
+		if !result.Removed {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+func printRemoveResult(w io.Writer, v interface{}) error {
+	r := v.(removeResult)
+	if r.Removed {
+		_, err := fmt.Fprintf(w, "Removed face profile %q.\n", r.Name)
+		return err
+	}
+	_, err := fmt.Fprintf(w, "No face profile named %q was found.\n", r.Name)
+	return err
+}
@@ -0,0 +1,88 @@
+// Package cmd implements the linuxwave-cli subcommands. Every subcommand
+// except the config ones talks to the linuxwave service over the Unix
+// socket configured in config.ServiceConfig.SocketPath; the config
+// subcommands read and write the configuration files directly.
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vinisadev/linuxwave/internal/config"
+	"github.com/vinisadev/linuxwave/internal/ipc"
+	"github.com/vinisadev/linuxwave/internal/logging"
+	"github.com/vinisadev/linuxwave/internal/output"
+)
+
+var outputFormat string
+
+// logger is shared by every subcommand so CLI logs follow the same level,
+// format, and attribute schema as the other linux-wave binaries. It is
+// populated by loadConfig, which every subcommand except `config validate`
+// calls first.
+var logger *slog.Logger
+
+var rootCmd = &cobra.Command{
+	Use:           "linuxwave-cli",
+	Short:         "Manage the linux-wave face authentication service",
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", "text", "output format: json|yaml|text")
+
+	rootCmd.AddCommand(enrollCmd)
+	rootCmd.AddCommand(listCmd)
+	rootCmd.AddCommand(removeCmd)
+	rootCmd.AddCommand(testCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(serviceCmd)
+}
+
+// Execute runs the root command, dispatching to the matching subcommand.
+func Execute() error {
+	return rootCmd.Execute()
+}
+
+// currentFormat validates and returns the --output flag value, exiting the
+// process with a non-zero status if it is invalid.
+func currentFormat() output.Format {
+	format, err := output.ParseFormat(outputFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	return format
+}
+
+// loadConfig loads and validates the configuration files with any
+// LINUXWAVE_* environment variable overrides applied, exiting the
+// process with a non-zero status on failure so scripts can detect it.
+func loadConfig() *config.Config {
+	cfg, err := config.LoadWithOverlay(os.Environ(), nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	logger, err = logging.New(cfg.Logging)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to initialize logging: %v\n", err)
+		os.Exit(1)
+	}
+
+	return cfg
+}
+
+// dialService opens a connection to the linuxwave service using the
+// socket path and timeout from cfg.
+func dialService(cfg *config.Config) (*ipc.Client, error) {
+	timeout := time.Duration(cfg.Service.Timeout) * time.Second
+	logger.Debug("dialing linuxwave service", "socket_path", cfg.Service.SocketPath, "timeout", timeout)
+	return ipc.Dial(cfg.Service.SocketPath, timeout)
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vinisadev/linuxwave/internal/output"
+)
+
+type serviceStatus struct {
+	Running       bool   `json:"running" yaml:"running"`
+	Version       string `json:"version" yaml:"version"`
+	UptimeSeconds int64  `json:"uptime_seconds" yaml:"uptime_seconds"`
+}
+
+var serviceCmd = &cobra.Command{
+	Use:   "service",
+	Short: "Inspect the linuxwave service",
+}
+
+var serviceStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Report whether the linuxwave service is running",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := loadConfig()
+
+		client, err := dialService(cfg)
+		if err != nil {
+			return fmt.Errorf("service is not reachable: %w", err)
+		}
+		defer client.Close()
+
+		var status serviceStatus
+		if err := client.Call("status", nil, &status); err != nil {
+			return fmt.Errorf("status failed: %w", err)
+		}
+
+		if err := output.Write(os.Stdout, currentFormat(), status, printServiceStatus); err != nil {
+			return err
+		}
+
+		if !status.Running {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	serviceCmd.AddCommand(serviceStatusCmd)
+}
+
+func printServiceStatus(w io.Writer, v interface{}) error {
+	s := v.(serviceStatus)
+	state := "stopped"
+	if s.Running {
+		state = "running"
+	}
+	_, err := fmt.Fprintf(w, "linuxwave-service: %s (version %s, uptime %ds)\n", state, s.Version, s.UptimeSeconds)
+	return err
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vinisadev/linuxwave/internal/output"
+)
+
+// testAuthResult is the service's reply to a "test" command, a dry-run
+// authentication attempt that never unlocks anything.
+type testAuthResult struct {
+	Matched    bool    `json:"matched" yaml:"matched"`
+	Confidence float64 `json:"confidence" yaml:"confidence"`
+	Message    string  `json:"message" yaml:"message"`
+}
+
+var testCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Run a test authentication attempt without unlocking anything",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := loadConfig()
+
+		client, err := dialService(cfg)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		var result testAuthResult
+		if err := client.Call("test", nil, &result); err != nil {
+			return fmt.Errorf("test authentication failed: %w", err)
+		}
+
+		if err := output.Write(os.Stdout, currentFormat(), result, printTestResult); err != nil {
+			return err
+		}
+
+		if !result.Matched {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+func printTestResult(w io.Writer, v interface{}) error {
+	r := v.(testAuthResult)
+	if r.Matched {
+		_, err := fmt.Fprintf(w, "Match (confidence %.2f): %s\n", r.Confidence, r.Message)
+		return err
+	}
+	_, err := fmt.Fprintf(w, "No match (confidence %.2f): %s\n", r.Confidence, r.Message)
+	return err
+}
@@ -1,14 +1,16 @@
+// Command linuxwave-cli is the management tool for the linux-wave face
+// authentication service: enrolling and removing face profiles, testing
+// authentication, and inspecting or changing configuration.
 package main
 
 import (
-	"fmt"
+	"os"
 
-	"github.com/vinisadev/linuxwave/internal"
+	"github.com/vinisadev/linuxwave/cmd/linuxwave-cli/cmd"
 )
 
-const version = "0.1.0"
-
 func main() {
-	fmt.Printf("Linux Wave CLI v%s\n", internal.Version())
-	fmt.Println("CLI management tool")
+	if err := cmd.Execute(); err != nil {
+		os.Exit(1)
+	}
 }
\ No newline at end of file
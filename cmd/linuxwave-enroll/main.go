@@ -1,14 +1,30 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"os"
 
-	"github.com/vinisadev/linuxwave/internal"
+	"github.com/vinisadev/linuxwave/internal/config"
+	"github.com/vinisadev/linuxwave/internal/logging"
 )
 
-const version = "0.1.0"
-
 func main() {
-	fmt.Printf("Linux Wave Enroll v%s\n", internal.Version())
-	fmt.Println("Enrollment GUI application")
-}
\ No newline at end of file
+	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	config.RegisterFlags(fs)
+	_ = fs.Parse(os.Args[1:]) // ExitOnError already exits the process on a parse failure
+
+	cfg, err := config.LoadWithOverlay(os.Environ(), fs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	logger, err := logging.New(cfg.Logging)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to initialize logging: %v\n", err)
+		os.Exit(1)
+	}
+
+	logger.Info("starting linux-wave enroll")
+}
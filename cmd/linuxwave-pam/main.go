@@ -1,14 +1,84 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
+	"log/slog"
+	"os"
+	"time"
 
-	"github.com/vinisadev/linuxwave/internal"
+	"github.com/vinisadev/linuxwave/internal/config"
+	"github.com/vinisadev/linuxwave/internal/events"
+	"github.com/vinisadev/linuxwave/internal/ipc"
+	"github.com/vinisadev/linuxwave/internal/logging"
 )
 
-const version = "0.1.0"
-
 func main() {
-	fmt.Printf("Linux Wave PAM v%s\n", internal.Version())
-	fmt.Println("PAM helper module binary")
-}
\ No newline at end of file
+	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	config.RegisterFlags(fs)
+	_ = fs.Parse(os.Args[1:]) // ExitOnError already exits the process on a parse failure
+
+	cfg, err := config.LoadWithOverlay(os.Environ(), fs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	logger, err := logging.New(cfg.Logging)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to initialize logging: %v\n", err)
+		os.Exit(1)
+	}
+
+	sessionID := fmt.Sprintf("%d-%d", os.Getpid(), time.Now().UnixNano())
+	sessionLogger := logging.WithSessionID(logger, sessionID)
+	sessionLogger.Info("pam helper invoked")
+
+	if until, locked := recentLockout(cfg, sessionLogger); locked {
+		sessionLogger.Warn("denying authentication during lockout", "locked_until", until)
+		os.Exit(1)
+	}
+}
+
+// recentLockout asks the service, over the control socket, for the
+// authentication event backlog and reports whether a lockout was recorded
+// within the configured LockoutDuration, so a locked-out user can't retry
+// face auth before it expires. Any failure to reach the service (it may
+// not be running yet) is treated as "not locked out" rather than denying
+// authentication outright.
+func recentLockout(cfg *config.Config, logger *slog.Logger) (until time.Time, locked bool) {
+	timeout := time.Duration(cfg.Service.Timeout) * time.Second
+	client, err := ipc.Dial(cfg.Service.SocketPath, timeout)
+	if err != nil {
+		logger.Warn("could not reach linuxwave service to check lockout state", "error", err)
+		return time.Time{}, false
+	}
+	defer client.Close()
+
+	respCh, err := client.Stream("events", map[string]string{"follow": "false"})
+	if err != nil {
+		logger.Warn("failed to query events", "error", err)
+		return time.Time{}, false
+	}
+
+	lockoutDuration := time.Duration(cfg.Security.LockoutDuration) * time.Second
+	cutoff := time.Now().Add(-lockoutDuration)
+
+	for resp := range respCh {
+		if !resp.OK {
+			continue
+		}
+		var backlog []events.Event
+		if err := json.Unmarshal(resp.Data, &backlog); err != nil {
+			continue
+		}
+		for _, e := range backlog {
+			if e.Type == events.TypeLockout && e.Timestamp.After(cutoff) {
+				return e.Timestamp.Add(lockoutDuration), true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -1,14 +1,104 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
 
-	"github.com/vinisadev/linuxwave/internal"
+	"github.com/vinisadev/linuxwave/internal/config"
+	"github.com/vinisadev/linuxwave/internal/events"
+	"github.com/vinisadev/linuxwave/internal/ipc"
+	"github.com/vinisadev/linuxwave/internal/logging"
+	"github.com/vinisadev/linuxwave/internal/sandbox"
 )
 
-const version = "0.1.0"
-
 func main() {
-	fmt.Printf("Linux Wave Service v%s\n", internal.Version())
-	fmt.Println("Face authentication systemd service")
-}
\ No newline at end of file
+	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	config.RegisterFlags(fs)
+	_ = fs.Parse(os.Args[1:]) // ExitOnError already exits the process on a parse failure
+
+	watcher, err := config.NewWatcher(fs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+	defer watcher.Close()
+
+	logger, err := logging.New(watcher.Current().Logging)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to initialize logging: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Audio volume, log level, and lockout duration can all change on a
+	// reload without restarting the daemon; the logger is the only one of
+	// those this stub currently acts on.
+	watcher.OnChange(func(old, new *config.Config) {
+		newLogger, err := logging.New(new.Logging)
+		if err != nil {
+			logger.Error("failed to apply reloaded logging config", slog.Any("error", err))
+			return
+		}
+		logger = newLogger
+		logger.Info("configuration reloaded",
+			"log_level", new.Logging.Level,
+			"audio_volume", new.Audio.Volume,
+			"lockout_duration", new.Security.LockoutDuration,
+		)
+	})
+
+	eventLog := events.NewLog(watcher.Current().Events)
+
+	server, err := ipc.NewServer(watcher.Current().Service.SocketPath, logger)
+	if err != nil {
+		logger.Error("failed to create control socket", slog.Any("error", err))
+		os.Exit(1)
+	}
+	defer server.Close()
+
+	server.HandleStream("events", func(args map[string]string, send func(interface{}) error) error {
+		follow, _ := strconv.ParseBool(args["follow"])
+
+		backlog, tail, unsubscribe := eventLog.Subscribe()
+		defer unsubscribe()
+
+		if err := send(backlog); err != nil {
+			return err
+		}
+		if !follow {
+			return nil
+		}
+		for e := range tail {
+			if err := send(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	// Privileged setup (binding the control socket, opening the camera
+	// device) must happen before this call; Apply drops the capabilities
+	// and syscalls that setup needed.
+	if err := sandbox.Apply(watcher.Current().Security); err != nil {
+		logger.Error("failed to sandbox the service", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	logger.Info("starting linux-wave service", "socket_path", watcher.Current().Service.SocketPath)
+
+	go func() {
+		if err := server.Serve(); err != nil {
+			logger.Info("control socket server stopped", slog.Any("error", err))
+		}
+	}()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	<-sigCh
+
+	logger.Info("shutting down linux-wave service")
+}
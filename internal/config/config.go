@@ -7,57 +7,65 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/stretchr/testify/assert/yaml"
+	"gopkg.in/yaml.v3"
 )
 
 // Config represents the complete linuxwave system configuration
 type Config struct {
-	Service ServiceConfig `yaml:"service"`
-	Logging LoggingConfig `yaml:"logging"`
-	Audio AudioConfig `yaml:"audio"`
-	Security SecurityConfig `yaml:"security"`
+	Service ServiceConfig `yaml:"service" json:"service"`
+	Logging LoggingConfig `yaml:"logging" json:"logging"`
+	Audio AudioConfig `yaml:"audio" json:"audio"`
+	Security SecurityConfig `yaml:"security" json:"security"`
+	Events EventsConfig `yaml:"events" json:"events"`
 }
 
 // ServiceConfig contains service-level settings for authentication operations
 type ServiceConfig struct {
 	// Timeout is the authentication timeout in seconds (1-60)
-	Timeout int `yaml:"timeout"`
+	Timeout int `yaml:"timeout" json:"timeout"`
 	// RetryAttempts is the maximum number of retry attempts for authentication (1-10)
-	RetryAttempts int `yaml:"retry_attempts"`
+	RetryAttempts int `yaml:"retry_attempts" json:"retry_attempts"`
 	// SocketPath is the Unix domain socket path for IPC communication (must be absolute)
-	SocketPath string `yaml:"socket_path"`
+	SocketPath string `yaml:"socket_path" json:"socket_path"`
 }
 
 // LoggingConfig contains logging configuration settings.
 type LoggingConfig struct {
 	// Level is the log verbosity level: DEBUG, INFO, WARN, ERROR
-	Level string `yaml:"level"`
+	Level string `yaml:"level" json:"level"`
 	// Format is the log output format: json (structured) or text (human-readable)
-	Format string `yaml:"format"`
+	Format string `yaml:"format" json:"format"`
 }
 
 // AudioConfig contains audio feedback configuration settings.
 type AudioConfig struct {
 	// Enabled controls whether audio feedback is enabled for authentication events
-	Enabled bool `yaml:"enabled"`
+	Enabled bool `yaml:"enabled" json:"enabled"`
 	// VOlume is the audio volume level (0-100)
-	Volume int `yaml:"volume"`
+	Volume int `yaml:"volume" json:"volume"`
 	// CustomSoundSuccess is the optional path to a custom success sound file
-	CustomSoundSuccess string `yaml:"custom_sound_success"`
+	CustomSoundSuccess string `yaml:"custom_sound_success" json:"custom_sound_success"`
 	// CustomSoundFailure is the optional path to a custom failure sound file
-	CustomSoundFailure string `yaml:"custom_sound_failure"`
+	CustomSoundFailure string `yaml:"custom_sound_failure" json:"custom_sound_failure"`
 }
 
 // SecurityConfig contains security-related settings
 type SecurityConfig struct {
 	// LivenessRequired enables liveness detection (blink/movement check)
-	LivenessRequired bool `yaml:"liveness_required"`
+	LivenessRequired bool `yaml:"liveness_required" json:"liveness_required"`
 	// MatchThreshold is the face matching confidence threshold (0.0-1.0)
-	MatchThreshold float64 `yaml:"match_threshold"`
+	MatchThreshold float64 `yaml:"match_threshold" json:"match_threshold"`
 	// MaxAuthAttempts is the maximum authentication attempts before lockout
-	MaxAuthAttempts int `yaml:"max_auth_attempts"`
+	MaxAuthAttempts int `yaml:"max_auth_attempts" json:"max_auth_attempts"`
 	// LockoutDuration is the lockout duration in seconds after max attempts succeeded
-	LockoutDuration int `yaml:"lockout_duration"`
+	LockoutDuration int `yaml:"lockout_duration" json:"lockout_duration"`
+}
+
+// EventsConfig contains settings for the in-memory authentication event log.
+type EventsConfig struct {
+	// RetainCount is the number of recent authentication events kept in
+	// memory for late subscribers (e.g. a CLI that reconnects).
+	RetainCount int `yaml:"retain_count" json:"retain_count"`
 }
 
 const (
@@ -79,7 +87,7 @@ func DefaultConfig() *Config {
 		},
 		Logging: LoggingConfig{
 			Level: "INFO", // INFO level provides useful feedback without excessive verbosity
-			Format: "test", // Human-readable format is better for system logs by default
+			Format: "text", // Human-readable format is better for system logs by default
 		},
 		Audio: AudioConfig{
 			Enabled: true, // Audio feedback improves accessibility and user experience
@@ -93,6 +101,9 @@ func DefaultConfig() *Config {
 			MaxAuthAttempts: 3, // Same as retry attempts for consistency
 			LockoutDuration: 300, // 5 minutes is standard for authentication lockouts
 		},
+		Events: EventsConfig{
+			RetainCount: 100, // enough backlog for a reconnecting monitor without unbounded growth
+		},
 	}
 }
 
@@ -109,16 +120,34 @@ func DefaultConfig() *Config {
 //
 // File not found is not an error; YAML parsing errors and validation errors are returned.
 func Load() (*Config, error) {
+	cfg, err := loadLayered()
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate merged configuration
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// loadLayered applies DefaultConfig(), then the system config file, then
+// the user config file, in that order, without validating the result.
+// It is shared by Load and LoadWithOverlay so both start from the same
+// file-based layers before LoadWithOverlay adds env vars and flags.
+func loadLayered() (*Config, error) {
 	// Start with defaults
 	cfg := DefaultConfig()
 
 	// Load system config if it exists
 	if _, err := os.Stat(systemConfigPath); err == nil {
-		systemCfg := &Config{}
-		if err := loadFromPath(systemConfigPath, systemCfg); err != nil {
+		systemCfg, present, err := loadFromPath(systemConfigPath)
+		if err != nil {
 			return nil, fmt.Errorf("failed to load system config: %w", err)
 		}
-		cfg = mergeConfigs(cfg, systemCfg)
+		cfg = mergeConfigs(cfg, systemCfg, present)
 	}
 
 	// Load user config if it exists
@@ -128,16 +157,11 @@ func Load() (*Config, error) {
 	}
 
 	if _, err := os.Stat(userConfigPath); err == nil {
-		userCfg := &Config{}
-		if err := loadFromPath(userConfigPath, userCfg); err != nil {
+		userCfg, present, err := loadFromPath(userConfigPath)
+		if err != nil {
 			return nil, fmt.Errorf("failed to load user config: %w", err)
 		}
-		cfg = mergeConfigs(cfg, userCfg)
-	}
-
-	// Validate merged configuration
-	if err := cfg.Validate(); err != nil {
-		return nil, fmt.Errorf("configuration validation failed: %w", err)
+		cfg = mergeConfigs(cfg, userCfg, present)
 	}
 
 	return cfg, nil
@@ -153,7 +177,11 @@ func LoadFromPath(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to expand path %s: %w", path, err)
 	}
 
-	if err := loadFromPath(expandedPath, cfg); err != nil {
+	data, err := os.ReadFile(expandedPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config from %s: %w", expandedPath, err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
 		return nil, fmt.Errorf("failed to load config from %s: %w", expandedPath, err)
 	}
 
@@ -164,73 +192,44 @@ func LoadFromPath(path string) (*Config, error) {
 	return cfg, nil
 }
 
-// loadFromPath is an internal helper that reads and unmarshals a YAML file.
-func loadFromPath(path string, cfg *Config) error {
+// loadFromPath reads and unmarshals a YAML config file, returning both the
+// parsed Config and the set of dotted keys (e.g. "audio.enabled") that were
+// actually present in the file. The presence set lets mergeConfigs apply an
+// override field whenever it was explicitly set, even to its zero value,
+// instead of guessing from whether it differs from the zero value.
+func loadFromPath(path string) (*Config, fieldSet, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
+		return nil, nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
+	cfg := &Config{}
 	if err := yaml.Unmarshal(data, cfg); err != nil {
-		return fmt.Errorf("failed to parse YAML: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
 
-	return nil
-}
-
-// mergeConfigs merges override config into base config, with override values
-// taking precedence for any non-zero values. This implements the user config
-// overriding system config behavior.
-func mergeConfigs(base, override *Config) *Config {
-	result := *base // Start with a copy of base
-
-	// Merge Service settings
-	if override.Service.Timeout != 0 {
-		result.Service.Timeout = override.Service.Timeout
-	}
-	if override.Service.RetryAttempts != 0 {
-		result.Service.RetryAttempts = override.Service.RetryAttempts
-	}
-	if override.Service.SocketPath != "" {
-		result.Service.SocketPath = override.Service.SocketPath
+	present, err := presentFieldsInYAML(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
 
-	// Merge Logging settings
-	if override.Logging.Level != "" {
-		result.Logging.Level = override.Logging.Level
-	}
-	if override.Logging.Format != "" {
-		result.Logging.Format = override.Logging.Format
-	}
+	return cfg, present, nil
+}
 
-	// Merge Audio settings
-	// Note: For boolean fields, we can't distinguish between explicit false and zero value
-	// This is acceptable since the user would set enabled: false explicitly if desired
-	if override.Audio.Enabled != base.Audio.Enabled {
-		result.Audio.Enabled = override.Audio.Enabled
-	}
-	if override.Audio.Volume != 0 {
-		result.Audio.Volume = override.Audio.Volume
-	}
-	if override.Audio.CustomSoundSuccess != "" {
-		result.Audio.CustomSoundSuccess = override.Audio.CustomSoundSuccess
-	}
-	if override.Audio.CustomSoundFailure != "" {
-		result.Audio.CustomSoundFailure = override.Audio.CustomSoundFailure
-	}
+// mergeConfigs merges override config into base config, applying only the
+// fields present in present (see loadFromPath). This implements the user
+// config overriding system config behavior, correctly for booleans whose
+// explicit value is false.
+func mergeConfigs(base, override *Config, present fieldSet) *Config {
+	result := *base // Start with a copy of base
 
-	// Merge Security settings
-	if override.Security.LivenessRequired != base.Security.LivenessRequired {
-		result.Security.LivenessRequired = override.Security.LivenessRequired
-	}
-	if override.Security.MatchThreshold != 0 {
-		result.Security.MatchThreshold = override.Security.MatchThreshold
-	}
-	if override.Security.MaxAuthAttempts != 0 {
-		result.Security.MaxAuthAttempts = override.Security.MaxAuthAttempts
-	}
-	if override.Security.LockoutDuration != 0 {
-		result.Security.LockoutDuration = override.Security.LockoutDuration
+	for _, key := range configKeys {
+		if !present[key] {
+			continue
+		}
+		// Values were already validated by YAML unmarshaling into override,
+		// so reusing SetByKey here can't fail.
+		_ = SetByKey(&result, key, fieldValueString(override, key))
 	}
 
 	return &result
@@ -292,6 +291,11 @@ func (c *Config) Validate() error {
 		errs = append(errs, errors.New("lockout_duration must be between 0 and 3600"))
 	}
 
+	// Validate Events settings
+	if c.Events.RetainCount <= 0 || c.Events.RetainCount > 10000 {
+		errs = append(errs, errors.New("events.retain_count must be between 1 and 10000"))
+	}
+
 	if len(errs) > 0 {
 		return errors.Join(errs...)
 	}
@@ -299,6 +303,104 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// UserConfigPath returns the expanded path to the user-specific
+// configuration file, the one Load merges over the system file and
+// LoadUserLayer reads.
+func UserConfigPath() (string, error) {
+	return expandPath(filepath.Join("~", userConfigRelPath))
+}
+
+// LoadUserLayer reads just the user config file's own layer: the fields
+// it explicitly sets, with everything else left at the zero value, plus
+// the set of dotted keys that were present. Unlike Load/LoadWithOverlay,
+// it does not start from DefaultConfig() or apply the system file, env,
+// or flags, so callers can modify one key and write back only what the
+// user actually asked for. If the file doesn't exist, it returns an
+// empty Config and an empty fieldSet, not an error, so a first
+// `config set` has something to merge into.
+func LoadUserLayer() (*Config, fieldSet, error) {
+	path, err := UserConfigPath()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return &Config{}, fieldSet{}, nil
+	}
+
+	cfg, present, err := loadFromPath(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load user config: %w", err)
+	}
+	return cfg, present, nil
+}
+
+// Marshal serializes c to YAML in the same shape Load expects to read back.
+func (c *Config) Marshal() ([]byte, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+	return data, nil
+}
+
+// SaveToPath writes c to path as YAML, creating parent directories as
+// needed. The write is atomic: it writes to a temp file in the same
+// directory, fsyncs it, renames it over path, then fsyncs the directory,
+// so a crash mid-write can never leave path holding a torn file.
+func (c *Config) SaveToPath(path string) error {
+	data, err := c.Marshal()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// writeFileAtomic writes data to path, creating parent directories as
+// needed. It writes to a temp file in the same directory, fsyncs it,
+// renames it over path, then fsyncs the directory, so a crash mid-write
+// can never leave path holding a torn file.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".config-*.yaml.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp config file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temp config file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync temp config file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temp config file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to move temp config file into place: %w", err)
+	}
+
+	dirHandle, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open config directory %s for fsync: %w", dir, err)
+	}
+	defer dirHandle.Close()
+	if err := dirHandle.Sync(); err != nil {
+		return fmt.Errorf("failed to fsync config directory %s: %w", dir, err)
+	}
+
+	return nil
+}
+
 // expandPath expands ~ to the user's home directory and resolves environment variables.
 func expandPath(path string) (string, error) {
 	if strings.HasPrefix(path, "~") {
@@ -319,6 +421,7 @@ func (c *Config) String() string {
   Logging: {Level: %s, Format: %s}
   Audio: {Enabled: %v, Volume: %d, CustomSuccess: %s, CustomFailure: %s}
   Security: {LivenessRequired: %v, MatchThreshold: %.2f, MaxAuthAttempts: %d, LockoutDuration: %ds}
+  Events: {RetainCount: %d}
 }`,
 		c.Service.Timeout,
 		c.Service.RetryAttempts,
@@ -333,6 +436,7 @@ func (c *Config) String() string {
 		c.Security.MatchThreshold,
 		c.Security.MaxAuthAttempts,
 		c.Security.LockoutDuration,
+		c.Events.RetainCount,
 	)
 }
 
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMarshalRoundTrip(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Audio.Volume = 42
+
+	data, err := cfg.Marshal()
+	assert.NoError(t, err)
+
+	roundTripped := &Config{}
+	assert.NoError(t, yaml.Unmarshal(data, roundTripped))
+	assert.Equal(t, cfg.Audio.Volume, roundTripped.Audio.Volume)
+}
+
+func TestSaveToPathWritesReadableYAML(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Logging.Level = "DEBUG"
+
+	path := filepath.Join(t.TempDir(), "nested", "config.yaml")
+	assert.NoError(t, cfg.SaveToPath(path))
+
+	loaded, err := LoadFromPath(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "DEBUG", loaded.Logging.Level)
+}
+
+func TestSaveToPathOverwritesExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	first := DefaultConfig()
+	first.Audio.Volume = 10
+	assert.NoError(t, first.SaveToPath(path))
+
+	second := DefaultConfig()
+	second.Audio.Volume = 90
+	assert.NoError(t, second.SaveToPath(path))
+
+	loaded, err := LoadFromPath(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 90, loaded.Audio.Volume)
+}
@@ -0,0 +1,337 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configKeys lists every dotted key (section.field) that SetByKey, the
+// environment variable overlay, and the CLI flag overlay understand.
+var configKeys = []string{
+	"service.timeout",
+	"service.retry_attempts",
+	"service.socket_path",
+	"logging.level",
+	"logging.format",
+	"audio.enabled",
+	"audio.volume",
+	"audio.custom_sound_success",
+	"audio.custom_sound_failure",
+	"security.liveness_required",
+	"security.match_threshold",
+	"security.max_auth_attempts",
+	"security.lockout_duration",
+	"events.retain_count",
+}
+
+// fieldSet records which dotted config keys were explicitly set, so a merge
+// or overlay can tell "set to the zero value" apart from "not set at all".
+type fieldSet map[string]bool
+
+// envPrefix is prepended to a dotted config key's upper-snake-case form to
+// build its environment variable name, e.g. "service.timeout" becomes
+// "LINUXWAVE_SERVICE_TIMEOUT".
+const envPrefix = "LINUXWAVE_"
+
+// envVarForKey returns the environment variable name for a dotted config key.
+func envVarForKey(key string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// SetByKey sets the field of cfg identified by a dotted key (e.g.
+// "audio.volume") by parsing value to that field's type. It is the single
+// place that knows how to map a config key to a struct field, shared by
+// `linuxwave-cli config set`, the environment variable overlay, and the
+// CLI flag overlay.
+func SetByKey(cfg *Config, key, value string) error {
+	switch key {
+	case "service.timeout":
+		return setIntField(&cfg.Service.Timeout, value)
+	case "service.retry_attempts":
+		return setIntField(&cfg.Service.RetryAttempts, value)
+	case "service.socket_path":
+		cfg.Service.SocketPath = value
+		return nil
+	case "logging.level":
+		cfg.Logging.Level = value
+		return nil
+	case "logging.format":
+		cfg.Logging.Format = value
+		return nil
+	case "audio.enabled":
+		return setBoolField(&cfg.Audio.Enabled, value)
+	case "audio.volume":
+		return setIntField(&cfg.Audio.Volume, value)
+	case "audio.custom_sound_success":
+		cfg.Audio.CustomSoundSuccess = value
+		return nil
+	case "audio.custom_sound_failure":
+		cfg.Audio.CustomSoundFailure = value
+		return nil
+	case "security.liveness_required":
+		return setBoolField(&cfg.Security.LivenessRequired, value)
+	case "security.match_threshold":
+		return setFloatField(&cfg.Security.MatchThreshold, value)
+	case "security.max_auth_attempts":
+		return setIntField(&cfg.Security.MaxAuthAttempts, value)
+	case "security.lockout_duration":
+		return setIntField(&cfg.Security.LockoutDuration, value)
+	case "events.retain_count":
+		return setIntField(&cfg.Events.RetainCount, value)
+	default:
+		return fmt.Errorf("unknown configuration key %q", key)
+	}
+}
+
+// fieldValueString returns the current value of the field identified by
+// key as a string, the inverse of SetByKey, used to move a value from one
+// parsed Config to another through the same key-based accessor.
+func fieldValueString(cfg *Config, key string) string {
+	switch key {
+	case "service.timeout":
+		return strconv.Itoa(cfg.Service.Timeout)
+	case "service.retry_attempts":
+		return strconv.Itoa(cfg.Service.RetryAttempts)
+	case "service.socket_path":
+		return cfg.Service.SocketPath
+	case "logging.level":
+		return cfg.Logging.Level
+	case "logging.format":
+		return cfg.Logging.Format
+	case "audio.enabled":
+		return strconv.FormatBool(cfg.Audio.Enabled)
+	case "audio.volume":
+		return strconv.Itoa(cfg.Audio.Volume)
+	case "audio.custom_sound_success":
+		return cfg.Audio.CustomSoundSuccess
+	case "audio.custom_sound_failure":
+		return cfg.Audio.CustomSoundFailure
+	case "security.liveness_required":
+		return strconv.FormatBool(cfg.Security.LivenessRequired)
+	case "security.match_threshold":
+		return strconv.FormatFloat(cfg.Security.MatchThreshold, 'f', -1, 64)
+	case "security.max_auth_attempts":
+		return strconv.Itoa(cfg.Security.MaxAuthAttempts)
+	case "security.lockout_duration":
+		return strconv.Itoa(cfg.Security.LockoutDuration)
+	case "events.retain_count":
+		return strconv.Itoa(cfg.Events.RetainCount)
+	default:
+		return ""
+	}
+}
+
+// fieldValue returns the current value of the field identified by key as
+// its native Go type (int, float64, bool, or string), for building a YAML
+// document that contains only the keys actually present rather than a
+// string-typed dump of the whole struct.
+func fieldValue(cfg *Config, key string) interface{} {
+	switch key {
+	case "service.timeout":
+		return cfg.Service.Timeout
+	case "service.retry_attempts":
+		return cfg.Service.RetryAttempts
+	case "service.socket_path":
+		return cfg.Service.SocketPath
+	case "logging.level":
+		return cfg.Logging.Level
+	case "logging.format":
+		return cfg.Logging.Format
+	case "audio.enabled":
+		return cfg.Audio.Enabled
+	case "audio.volume":
+		return cfg.Audio.Volume
+	case "audio.custom_sound_success":
+		return cfg.Audio.CustomSoundSuccess
+	case "audio.custom_sound_failure":
+		return cfg.Audio.CustomSoundFailure
+	case "security.liveness_required":
+		return cfg.Security.LivenessRequired
+	case "security.match_threshold":
+		return cfg.Security.MatchThreshold
+	case "security.max_auth_attempts":
+		return cfg.Security.MaxAuthAttempts
+	case "security.lockout_duration":
+		return cfg.Security.LockoutDuration
+	case "events.retain_count":
+		return cfg.Events.RetainCount
+	default:
+		return nil
+	}
+}
+
+// MarshalKeys serializes only the dotted keys in present from cfg, as a
+// nested YAML document. SaveUserLayer uses it so that writing back a
+// single changed key never also bakes in defaults, the system config, or
+// a transient environment/flag overlay as if the user had set them.
+func MarshalKeys(cfg *Config, present fieldSet) ([]byte, error) {
+	sections := map[string]map[string]interface{}{}
+	for _, key := range configKeys {
+		if !present[key] {
+			continue
+		}
+		section, field, _ := strings.Cut(key, ".")
+		if sections[section] == nil {
+			sections[section] = map[string]interface{}{}
+		}
+		sections[section][field] = fieldValue(cfg, key)
+	}
+
+	data, err := yaml.Marshal(sections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+	return data, nil
+}
+
+// SaveUserLayer writes only the dotted keys in present from cfg to path,
+// using the same atomic write as Config.SaveToPath.
+func SaveUserLayer(path string, cfg *Config, present fieldSet) error {
+	data, err := MarshalKeys(cfg, present)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+func setIntField(field *int, value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("expected an integer, got %q", value)
+	}
+	*field = n
+	return nil
+}
+
+func setBoolField(field *bool, value string) error {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("expected true or false, got %q", value)
+	}
+	*field = b
+	return nil
+}
+
+func setFloatField(field *float64, value string) error {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("expected a decimal number, got %q", value)
+	}
+	*field = f
+	return nil
+}
+
+// presentFieldsInYAML parses data as a nested YAML mapping and returns the
+// set of "section.field" keys that were actually present, so callers can
+// distinguish an explicitly-set zero value from a field that was absent.
+func presentFieldsInYAML(data []byte) (fieldSet, error) {
+	var raw map[string]map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	present := make(fieldSet)
+	for section, fields := range raw {
+		for field := range fields {
+			present[section+"."+field] = true
+		}
+	}
+	return present, nil
+}
+
+// LoadWithOverlay loads configuration the same way Load does (defaults,
+// then system YAML, then user YAML), then layers environment variables
+// named LINUXWAVE_<SECTION>_<FIELD> (e.g. LINUXWAVE_SERVICE_TIMEOUT) and,
+// if flags is non-nil, command-line flags named after their dotted config
+// key (e.g. -service.timeout=15) on top, in that order. env is typically
+// os.Environ(). Only variables/flags that were actually set are applied.
+func LoadWithOverlay(env []string, flags *flag.FlagSet) (*Config, error) {
+	cfg, err := loadLayered()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyEnvOverlay(cfg, env); err != nil {
+		return nil, err
+	}
+
+	if err := applyFlagOverlay(cfg, flags); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// applyEnvOverlay applies any LINUXWAVE_* variables present in env onto cfg.
+func applyEnvOverlay(cfg *Config, env []string) error {
+	values := make(map[string]string, len(env))
+	for _, kv := range env {
+		name, value, ok := strings.Cut(kv, "=")
+		if ok {
+			values[name] = value
+		}
+	}
+
+	for _, key := range configKeys {
+		value, ok := values[envVarForKey(key)]
+		if !ok {
+			continue
+		}
+		if err := SetByKey(cfg, key, value); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", envVarForKey(key), err)
+		}
+	}
+
+	return nil
+}
+
+// RegisterFlags registers one flag per dotted config key (e.g.
+// -service.timeout=30) on fs, for cmd/* binaries that want the
+// command-line overlay layer LoadWithOverlay applies on top of env vars.
+// Flags left at their default are invisible to applyFlagOverlay (see
+// flag.FlagSet.Visit); only ones actually passed on the command line
+// override the loaded config.
+func RegisterFlags(fs *flag.FlagSet) {
+	fs.Int("service.timeout", 0, "override service.timeout (seconds)")
+	fs.Int("service.retry_attempts", 0, "override service.retry_attempts")
+	fs.String("service.socket_path", "", "override service.socket_path")
+	fs.String("logging.level", "", "override logging.level")
+	fs.String("logging.format", "", "override logging.format")
+	fs.Bool("audio.enabled", false, "override audio.enabled")
+	fs.Int("audio.volume", 0, "override audio.volume")
+	fs.String("audio.custom_sound_success", "", "override audio.custom_sound_success")
+	fs.String("audio.custom_sound_failure", "", "override audio.custom_sound_failure")
+	fs.Bool("security.liveness_required", false, "override security.liveness_required")
+	fs.Float64("security.match_threshold", 0, "override security.match_threshold")
+	fs.Int("security.max_auth_attempts", 0, "override security.max_auth_attempts")
+	fs.Int("security.lockout_duration", 0, "override security.lockout_duration")
+	fs.Int("events.retain_count", 0, "override events.retain_count")
+}
+
+// applyFlagOverlay applies any flags explicitly passed on the command line
+// (per flags.Visit, which skips flags left at their default) onto cfg.
+// Flag names must match a dotted config key.
+func applyFlagOverlay(cfg *Config, flags *flag.FlagSet) error {
+	if flags == nil {
+		return nil
+	}
+
+	var firstErr error
+	flags.Visit(func(f *flag.Flag) {
+		if firstErr != nil {
+			return
+		}
+		if err := SetByKey(cfg, f.Name, f.Value.String()); err != nil {
+			firstErr = fmt.Errorf("invalid value for flag -%s: %w", f.Name, err)
+		}
+	})
+	return firstErr
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"flag"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPresentFieldsInYAML(t *testing.T) {
+	data := []byte("audio:\n  enabled: false\nlogging:\n  level: DEBUG\n")
+
+	present, err := presentFieldsInYAML(data)
+	assert.NoError(t, err)
+	assert.True(t, present["audio.enabled"])
+	assert.True(t, present["logging.level"])
+	assert.False(t, present["logging.format"])
+}
+
+func TestMergeConfigsAppliesExplicitFalse(t *testing.T) {
+	base := DefaultConfig()
+	assert.True(t, base.Audio.Enabled, "default should be true so this test can prove false overrides it")
+
+	override := DefaultConfig()
+	override.Audio.Enabled = false
+	override.Security.LivenessRequired = false
+
+	present := fieldSet{"audio.enabled": true, "security.liveness_required": true}
+	merged := mergeConfigs(base, override, present)
+
+	assert.False(t, merged.Audio.Enabled, "explicit false should override the true default")
+	assert.False(t, merged.Security.LivenessRequired, "explicit false should override the true default")
+}
+
+func TestMergeConfigsLeavesUnsetFieldsAlone(t *testing.T) {
+	base := DefaultConfig()
+	override := &Config{} // zero-value override, nothing present
+
+	merged := mergeConfigs(base, override, fieldSet{})
+
+	assert.Equal(t, base.Audio.Enabled, merged.Audio.Enabled)
+	assert.Equal(t, base.Service.Timeout, merged.Service.Timeout)
+}
+
+func TestSetByKeyUnknownKey(t *testing.T) {
+	cfg := DefaultConfig()
+	err := SetByKey(cfg, "audio.nonexistent", "1")
+	assert.Error(t, err)
+}
+
+func TestApplyEnvOverlay(t *testing.T) {
+	cfg := DefaultConfig()
+	env := []string{
+		"LINUXWAVE_AUDIO_VOLUME=75",
+		"LINUXWAVE_AUDIO_ENABLED=false",
+		"UNRELATED=ignored",
+	}
+
+	err := applyEnvOverlay(cfg, env)
+	assert.NoError(t, err)
+	assert.Equal(t, 75, cfg.Audio.Volume)
+	assert.False(t, cfg.Audio.Enabled)
+}
+
+func TestApplyEnvOverlayInvalidValue(t *testing.T) {
+	cfg := DefaultConfig()
+	err := applyEnvOverlay(cfg, []string{"LINUXWAVE_AUDIO_VOLUME=loud"})
+	assert.Error(t, err)
+}
+
+func TestMarshalKeysOnlyIncludesPresentFields(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Audio.Volume = 75
+	cfg.Logging.Level = "DEBUG"
+
+	data, err := MarshalKeys(cfg, fieldSet{"audio.volume": true})
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "volume: 75")
+	assert.NotContains(t, string(data), "level")
+}
+
+func TestSaveUserLayerWritesOnlyPresentKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	cfg := &Config{}
+	cfg.Audio.Volume = 75
+	assert.NoError(t, SaveUserLayer(path, cfg, fieldSet{"audio.volume": true}))
+
+	loaded, present, err := loadFromPath(path)
+	assert.NoError(t, err)
+	assert.True(t, present["audio.volume"])
+	assert.False(t, present["service.timeout"])
+	assert.Equal(t, 75, loaded.Audio.Volume)
+}
+
+func TestApplyFlagOverlayOnlyAppliesChangedFlags(t *testing.T) {
+	cfg := DefaultConfig()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("service.timeout", cfg.Service.Timeout, "")
+	fs.Bool("audio.enabled", cfg.Audio.Enabled, "")
+	assert.NoError(t, fs.Parse([]string{"-service.timeout=30"}))
+
+	err := applyFlagOverlay(cfg, fs)
+	assert.NoError(t, err)
+	assert.Equal(t, 30, cfg.Service.Timeout)
+	assert.True(t, cfg.Audio.Enabled, "unset flag should not override the default")
+}
+
+func TestRegisterFlagsAppliesViaApplyFlagOverlay(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	RegisterFlags(fs)
+	assert.NoError(t, fs.Parse([]string{"-service.timeout=42"}))
+
+	cfg := DefaultConfig()
+	assert.NoError(t, applyFlagOverlay(cfg, fs))
+	assert.Equal(t, 42, cfg.Service.Timeout)
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// OnChangeFunc is called after a Watcher successfully reloads
+// configuration, receiving the previous and newly validated Config.
+type OnChangeFunc func(old, new *Config)
+
+// errHolder lets a possibly-nil error be stored in an atomic.Pointer,
+// which cannot hold the nil interface value directly.
+type errHolder struct {
+	err error
+}
+
+// Watcher re-parses and validates configuration whenever SIGHUP is
+// received or the system/user config files change on disk, atomically
+// swapping in the new Config so subsystems never observe a half-applied
+// reload. A failed reload leaves the previous Config in place.
+type Watcher struct {
+	current atomic.Pointer[Config]
+	lastErr atomic.Pointer[errHolder]
+
+	mu        sync.Mutex
+	callbacks []OnChangeFunc
+
+	fsWatcher *fsnotify.Watcher
+	sigCh     chan os.Signal
+	errCh     chan error
+	done      chan struct{}
+
+	flags *flag.FlagSet
+}
+
+// NewWatcher loads the initial configuration and starts watching for
+// SIGHUP and config file changes in the background. Call Close to stop.
+// flags, if non-nil, is re-applied on every reload the same way it is
+// here, so a command-line override survives a SIGHUP or on-disk change.
+func NewWatcher(flags *flag.FlagSet) (*Watcher, error) {
+	cfg, err := LoadWithOverlay(os.Environ(), flags)
+	if err != nil {
+		return nil, err
+	}
+
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create config file watcher: %w", err)
+	}
+
+	paths, err := watchedPaths()
+	if err != nil {
+		fsWatcher.Close()
+		return nil, err
+	}
+	for _, path := range paths {
+		// The directory may not exist yet (e.g. no user config has ever
+		// been written); that just means changes there won't be picked
+		// up until it does, which is not fatal to starting the watcher.
+		_ = fsWatcher.Add(filepath.Dir(path))
+	}
+
+	w := &Watcher{
+		fsWatcher: fsWatcher,
+		sigCh:     make(chan os.Signal, 1),
+		errCh:     make(chan error, 1),
+		done:      make(chan struct{}),
+		flags:     flags,
+	}
+	w.current.Store(cfg)
+
+	signal.Notify(w.sigCh, syscall.SIGHUP)
+
+	go w.run()
+
+	return w, nil
+}
+
+// Current returns the most recently, successfully loaded configuration.
+func (w *Watcher) Current() *Config {
+	return w.current.Load()
+}
+
+// OnChange registers a callback invoked after every successful reload.
+// Callbacks run synchronously on the watcher's goroutine in registration
+// order, so they should return quickly.
+func (w *Watcher) OnChange(cb OnChangeFunc) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.callbacks = append(w.callbacks, cb)
+}
+
+// LastError returns the most recent error, from either a failed reload
+// attempt or the underlying file watcher. A successful reload resets it
+// to nil.
+func (w *Watcher) LastError() error {
+	h := w.lastErr.Load()
+	if h == nil {
+		return nil
+	}
+	return h.err
+}
+
+// Errors returns a channel that receives reload failures as they happen.
+// It holds one unread failure; a failure that arrives while one is still
+// unread is not sent, though it is still retrievable via LastError.
+func (w *Watcher) Errors() <-chan error {
+	return w.errCh
+}
+
+// Close stops watching for changes and releases the underlying file
+// watcher and signal handler.
+func (w *Watcher) Close() error {
+	signal.Stop(w.sigCh)
+	close(w.done)
+	return w.fsWatcher.Close()
+}
+
+func (w *Watcher) run() {
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-w.sigCh:
+			w.reload()
+		case event, ok := <-w.fsWatcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+				w.reload()
+			}
+		case err, ok := <-w.fsWatcher.Errors:
+			if !ok {
+				return
+			}
+			w.setLastError(err)
+		}
+	}
+}
+
+func (w *Watcher) reload() {
+	cfg, err := LoadWithOverlay(os.Environ(), w.flags)
+	if err != nil {
+		w.setLastError(err)
+		select {
+		case w.errCh <- err:
+		default:
+		}
+		return
+	}
+
+	old := w.current.Load()
+	w.current.Store(cfg)
+	w.setLastError(nil)
+
+	w.mu.Lock()
+	callbacks := append([]OnChangeFunc(nil), w.callbacks...)
+	w.mu.Unlock()
+
+	for _, cb := range callbacks {
+		cb(old, cfg)
+	}
+}
+
+func (w *Watcher) setLastError(err error) {
+	w.lastErr.Store(&errHolder{err: err})
+}
+
+// watchedPaths returns the system and user config file paths a Watcher
+// should watch for changes.
+func watchedPaths() ([]string, error) {
+	userPath, err := expandPath(filepath.Join("~", userConfigRelPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand user config path: %w", err)
+	}
+	return []string{systemConfigPath, userPath}, nil
+}
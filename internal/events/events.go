@@ -0,0 +1,124 @@
+// Package events keeps a bounded, in-memory record of recent authentication
+// events (enroll, match success/failure, liveness fail, lockout) that the
+// service publishes and the CLI and PAM helper subscribe to over the
+// control socket. Only the most recent RetainCount events are kept; late
+// subscribers receive that backlog followed by the live tail.
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/vinisadev/linuxwave/internal/config"
+)
+
+// Type identifies the kind of authentication event.
+type Type string
+
+const (
+	TypeEnroll       Type = "enroll"
+	TypeMatchSuccess Type = "match_success"
+	TypeMatchFailure Type = "match_failure"
+	TypeLivenessFail Type = "liveness_fail"
+	TypeLockout      Type = "lockout"
+)
+
+// subscriberBufferSize bounds how far a subscriber can lag before new
+// events are dropped for it rather than blocking the publisher.
+const subscriberBufferSize = 32
+
+// Event is a single authentication event, JSON-serializable for delivery
+// over the control socket.
+type Event struct {
+	Type       Type              `json:"type"`
+	Timestamp  time.Time         `json:"timestamp"`
+	Message    string            `json:"message,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Log is a bounded, rotating ring buffer of recent Events with
+// subscribable live updates.
+type Log struct {
+	mu          sync.Mutex
+	retainCount int
+	events      []Event
+	subscribers map[int]chan Event
+	nextSubID   int
+}
+
+// NewLog creates a Log retaining cfg.RetainCount events.
+func NewLog(cfg config.EventsConfig) *Log {
+	retain := cfg.RetainCount
+	if retain <= 0 {
+		retain = 100
+	}
+	return &Log{
+		retainCount: retain,
+		events:      make([]Event, 0, retain),
+		subscribers: make(map[int]chan Event),
+	}
+}
+
+// Publish appends e to the log, dropping the oldest event past
+// RetainCount, and fans it out to every live subscriber. A subscriber
+// that is too far behind to accept e without blocking misses it.
+func (l *Log) Publish(e Event) {
+	l.mu.Lock()
+	l.events = append(l.events, e)
+	if len(l.events) > l.retainCount {
+		l.events = l.events[len(l.events)-l.retainCount:]
+	}
+	subs := make([]chan Event, 0, len(l.subscribers))
+	for _, ch := range l.subscribers {
+		subs = append(subs, ch)
+	}
+	l.mu.Unlock()
+
+	for _, ch := range subs {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+// Since returns every retained event with a timestamp after t, letting a
+// reconnecting monitor fetch what it missed.
+func (l *Log) Since(t time.Time) []Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	result := make([]Event, 0, len(l.events))
+	for _, e := range l.events {
+		if e.Timestamp.After(t) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Subscribe returns the currently retained backlog, a channel delivering
+// the live tail, and an unsubscribe function the caller must invoke when
+// done to release the subscription.
+func (l *Log) Subscribe() (backlog []Event, tail <-chan Event, unsubscribe func()) {
+	l.mu.Lock()
+	backlog = make([]Event, len(l.events))
+	copy(backlog, l.events)
+
+	id := l.nextSubID
+	l.nextSubID++
+	ch := make(chan Event, subscriberBufferSize)
+	l.subscribers[id] = ch
+	l.mu.Unlock()
+
+	unsubscribe = func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if _, ok := l.subscribers[id]; ok {
+			delete(l.subscribers, id)
+			close(ch)
+		}
+	}
+
+	return backlog, ch, unsubscribe
+}
@@ -0,0 +1,64 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vinisadev/linuxwave/internal/config"
+)
+
+func TestLogRetainsOnlyRetainCount(t *testing.T) {
+	log := NewLog(config.EventsConfig{RetainCount: 2})
+
+	log.Publish(Event{Type: TypeEnroll, Timestamp: time.Now()})
+	log.Publish(Event{Type: TypeMatchSuccess, Timestamp: time.Now()})
+	log.Publish(Event{Type: TypeMatchFailure, Timestamp: time.Now()})
+
+	backlog, _, unsubscribe := log.Subscribe()
+	defer unsubscribe()
+
+	assert.Len(t, backlog, 2, "only the most recent RetainCount events should be kept")
+	assert.Equal(t, TypeMatchSuccess, backlog[0].Type)
+	assert.Equal(t, TypeMatchFailure, backlog[1].Type)
+}
+
+func TestSubscribeReceivesBacklogThenLiveTail(t *testing.T) {
+	log := NewLog(config.EventsConfig{RetainCount: 10})
+	log.Publish(Event{Type: TypeEnroll, Timestamp: time.Now()})
+
+	backlog, tail, unsubscribe := log.Subscribe()
+	defer unsubscribe()
+	assert.Len(t, backlog, 1)
+
+	log.Publish(Event{Type: TypeLockout, Timestamp: time.Now()})
+
+	select {
+	case e := <-tail:
+		assert.Equal(t, TypeLockout, e.Type)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for live event")
+	}
+}
+
+func TestSince(t *testing.T) {
+	log := NewLog(config.EventsConfig{RetainCount: 10})
+	cutoff := time.Now()
+
+	log.Publish(Event{Type: TypeLivenessFail, Timestamp: cutoff.Add(-time.Minute)})
+	log.Publish(Event{Type: TypeLockout, Timestamp: cutoff.Add(time.Minute)})
+
+	recent := log.Since(cutoff)
+	assert.Len(t, recent, 1)
+	assert.Equal(t, TypeLockout, recent[0].Type)
+}
+
+func TestUnsubscribeClosesChannel(t *testing.T) {
+	log := NewLog(config.EventsConfig{RetainCount: 10})
+	_, tail, unsubscribe := log.Subscribe()
+	unsubscribe()
+
+	_, ok := <-tail
+	assert.False(t, ok, "tail channel should be closed after unsubscribe")
+}
@@ -0,0 +1,102 @@
+// Package ipc implements both sides of the Unix socket protocol used by
+// linuxwave-cli and the PAM helper to talk to the linuxwave service: the
+// client here and the service's Server in server.go.
+package ipc
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+)
+
+// Request is a single command sent to the service over the control socket.
+type Request struct {
+	Command string            `json:"command"`
+	Args    map[string]string `json:"args,omitempty"`
+}
+
+// Response is the service's reply to a Request. Data carries the
+// command-specific payload and is only meaningful when OK is true.
+type Response struct {
+	OK    bool            `json:"ok"`
+	Data  json.RawMessage `json:"data,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+// Client is a connection to the linuxwave service control socket.
+type Client struct {
+	conn net.Conn
+	enc  *json.Encoder
+	dec  *json.Decoder
+}
+
+// Dial connects to the service's Unix domain socket at socketPath, failing
+// if the connection cannot be established within timeout.
+func Dial(socketPath string, timeout time.Duration) (*Client, error) {
+	conn, err := net.DialTimeout("unix", socketPath, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to linuxwave service at %s: %w", socketPath, err)
+	}
+	return &Client{
+		conn: conn,
+		enc:  json.NewEncoder(conn),
+		dec:  json.NewDecoder(bufio.NewReader(conn)),
+	}, nil
+}
+
+// Close closes the underlying socket connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Call sends command with args to the service and decodes the response's
+// Data field into result, if result is non-nil. It returns an error if the
+// service reports failure or the round trip cannot complete.
+func (c *Client) Call(command string, args map[string]string, result interface{}) error {
+	if err := c.enc.Encode(Request{Command: command, Args: args}); err != nil {
+		return fmt.Errorf("failed to send %q request: %w", command, err)
+	}
+
+	var resp Response
+	if err := c.dec.Decode(&resp); err != nil {
+		return fmt.Errorf("failed to read response to %q request: %w", command, err)
+	}
+
+	if !resp.OK {
+		return fmt.Errorf("service rejected %q request: %s", command, resp.Error)
+	}
+
+	if result != nil && len(resp.Data) > 0 {
+		if err := json.Unmarshal(resp.Data, result); err != nil {
+			return fmt.Errorf("failed to decode %q response: %w", command, err)
+		}
+	}
+
+	return nil
+}
+
+// Stream sends command with args and returns a channel of every Response
+// subsequently decoded from the connection, for commands such as
+// "events --follow" where the service keeps pushing updates instead of
+// replying once. The channel is closed when the connection ends.
+func (c *Client) Stream(command string, args map[string]string) (<-chan Response, error) {
+	if err := c.enc.Encode(Request{Command: command, Args: args}); err != nil {
+		return nil, fmt.Errorf("failed to send %q request: %w", command, err)
+	}
+
+	ch := make(chan Response)
+	go func() {
+		defer close(ch)
+		for {
+			var resp Response
+			if err := c.dec.Decode(&resp); err != nil {
+				return
+			}
+			ch <- resp
+		}
+	}()
+
+	return ch, nil
+}
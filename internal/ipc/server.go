@@ -0,0 +1,134 @@
+package ipc
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+)
+
+// HandlerFunc answers a single request/response command: it returns the
+// value to place in Response.Data (marshaled to JSON), or an error to
+// report as Response.Error.
+type HandlerFunc func(args map[string]string) (interface{}, error)
+
+// StreamHandlerFunc answers a command that keeps pushing responses over
+// the same connection until it returns, such as "events --follow" where
+// the service pushes the buffered backlog and then every live event as
+// it happens. send encodes one Response per call; StreamHandlerFunc
+// returns once there is nothing more to send, which closes the
+// connection.
+type StreamHandlerFunc func(args map[string]string, send func(data interface{}) error) error
+
+// Server is the service side of the Unix socket protocol linuxwave-cli
+// and the PAM helper speak to over config.ServiceConfig.SocketPath.
+type Server struct {
+	listener net.Listener
+	logger   *slog.Logger
+
+	handlers map[string]HandlerFunc
+	streams  map[string]StreamHandlerFunc
+}
+
+// NewServer creates the control socket at socketPath, removing a stale
+// socket file left behind by a previous run first. Call Serve to start
+// accepting connections.
+func NewServer(socketPath string, logger *slog.Logger) (*Server, error) {
+	if err := os.MkdirAll(filepath.Dir(socketPath), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	if err := os.RemoveAll(socketPath); err != nil {
+		return nil, fmt.Errorf("failed to remove stale socket %s: %w", socketPath, err)
+	}
+
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", socketPath, err)
+	}
+
+	return &Server{
+		listener: ln,
+		logger:   logger,
+		handlers: make(map[string]HandlerFunc),
+		streams:  make(map[string]StreamHandlerFunc),
+	}, nil
+}
+
+// Handle registers fn to answer command with a single Response.
+func (s *Server) Handle(command string, fn HandlerFunc) {
+	s.handlers[command] = fn
+}
+
+// HandleStream registers fn to answer command with one or more Responses
+// over the life of the connection.
+func (s *Server) HandleStream(command string, fn StreamHandlerFunc) {
+	s.streams[command] = fn
+}
+
+// Serve accepts connections until the listener is closed, handling each
+// on its own goroutine. It returns the listener's Accept error, which is
+// expected once Close has been called.
+func (s *Server) Serve() error {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return err
+		}
+		go s.handleConn(conn)
+	}
+}
+
+// Close stops accepting new connections.
+func (s *Server) Close() error {
+	return s.listener.Close()
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	dec := json.NewDecoder(bufio.NewReader(conn))
+	enc := json.NewEncoder(conn)
+
+	var req Request
+	if err := dec.Decode(&req); err != nil {
+		return
+	}
+
+	if fn, ok := s.streams[req.Command]; ok {
+		send := func(data interface{}) error {
+			raw, err := json.Marshal(data)
+			if err != nil {
+				return fmt.Errorf("failed to marshal %q response: %w", req.Command, err)
+			}
+			return enc.Encode(Response{OK: true, Data: raw})
+		}
+		if err := fn(req.Args, send); err != nil {
+			s.logger.Warn("stream command failed", "command", req.Command, "error", err)
+			_ = enc.Encode(Response{OK: false, Error: err.Error()})
+		}
+		return
+	}
+
+	fn, ok := s.handlers[req.Command]
+	if !ok {
+		_ = enc.Encode(Response{OK: false, Error: fmt.Sprintf("unknown command %q", req.Command)})
+		return
+	}
+
+	data, err := fn(req.Args)
+	if err != nil {
+		_ = enc.Encode(Response{OK: false, Error: err.Error()})
+		return
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		s.logger.Error("failed to marshal response", "command", req.Command, "error", err)
+		_ = enc.Encode(Response{OK: false, Error: "failed to marshal response"})
+		return
+	}
+	_ = enc.Encode(Response{OK: true, Data: raw})
+}
@@ -0,0 +1,75 @@
+package ipc
+
+import (
+	"encoding/json"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T) (*Server, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.sock")
+	srv, err := NewServer(path, slog.Default())
+	assert.NoError(t, err)
+	go srv.Serve()
+	t.Cleanup(func() { srv.Close() })
+	return srv, path
+}
+
+func TestServerHandleRoundTrip(t *testing.T) {
+	srv, path := newTestServer(t)
+	srv.Handle("ping", func(args map[string]string) (interface{}, error) {
+		return map[string]string{"pong": args["echo"]}, nil
+	})
+
+	client, err := Dial(path, time.Second)
+	assert.NoError(t, err)
+	defer client.Close()
+
+	var result map[string]string
+	assert.NoError(t, client.Call("ping", map[string]string{"echo": "hi"}, &result))
+	assert.Equal(t, "hi", result["pong"])
+}
+
+func TestServerUnknownCommand(t *testing.T) {
+	_, path := newTestServer(t)
+
+	client, err := Dial(path, time.Second)
+	assert.NoError(t, err)
+	defer client.Close()
+
+	err = client.Call("nonexistent", nil, nil)
+	assert.Error(t, err)
+}
+
+func TestServerHandleStreamSendsMultipleResponses(t *testing.T) {
+	srv, path := newTestServer(t)
+	srv.HandleStream("countdown", func(args map[string]string, send func(interface{}) error) error {
+		for i := 3; i > 0; i-- {
+			if err := send(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	client, err := Dial(path, time.Second)
+	assert.NoError(t, err)
+	defer client.Close()
+
+	respCh, err := client.Stream("countdown", nil)
+	assert.NoError(t, err)
+
+	var got []int
+	for resp := range respCh {
+		assert.True(t, resp.OK)
+		var n int
+		assert.NoError(t, json.Unmarshal(resp.Data, &n))
+		got = append(got, n)
+	}
+	assert.Equal(t, []int{3, 2, 1}, got)
+}
@@ -0,0 +1,63 @@
+// Package logging builds the shared *slog.Logger used by every linux-wave
+// binary, configured from config.LoggingConfig so enroll, service, pam, and
+// cli all emit logs with the same level, format, and attribute schema.
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/vinisadev/linuxwave/internal/config"
+)
+
+// New builds an *slog.Logger writing to stderr, configured from cfg.
+func New(cfg config.LoggingConfig) (*slog.Logger, error) {
+	level, err := parseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	switch strings.ToLower(cfg.Format) {
+	case "json":
+		handler = slog.NewJSONHandler(os.Stderr, opts)
+	case "text":
+		handler = slog.NewTextHandler(os.Stderr, opts)
+	default:
+		return nil, fmt.Errorf("unknown log format %q: must be json or text", cfg.Format)
+	}
+
+	return slog.New(handler), nil
+}
+
+// parseLevel maps a config.LoggingConfig.Level string to an slog.Level.
+func parseLevel(level string) (slog.Level, error) {
+	switch strings.ToUpper(level) {
+	case "DEBUG":
+		return slog.LevelDebug, nil
+	case "INFO":
+		return slog.LevelInfo, nil
+	case "WARN":
+		return slog.LevelWarn, nil
+	case "ERROR":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q: must be DEBUG, INFO, WARN, or ERROR", level)
+	}
+}
+
+// WithRequestID returns a logger that attaches request_id to every record,
+// letting a single CLI invocation or service call be traced through logs.
+func WithRequestID(logger *slog.Logger, requestID string) *slog.Logger {
+	return logger.With(slog.String("request_id", requestID))
+}
+
+// WithSessionID returns a logger that attaches session_id to every record,
+// letting a single authentication session be traced through logs.
+func WithSessionID(logger *slog.Logger, sessionID string) *slog.Logger {
+	return logger.With(slog.String("session_id", sessionID))
+}
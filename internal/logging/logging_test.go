@@ -0,0 +1,37 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vinisadev/linuxwave/internal/config"
+)
+
+func TestNewValidLevelsAndFormats(t *testing.T) {
+	for _, level := range []string{"DEBUG", "INFO", "WARN", "ERROR", "debug"} {
+		for _, format := range []string{"json", "text"} {
+			logger, err := New(config.LoggingConfig{Level: level, Format: format})
+			assert.NoError(t, err, "level=%s format=%s", level, format)
+			assert.NotNil(t, logger, "level=%s format=%s", level, format)
+		}
+	}
+}
+
+func TestNewInvalidLevel(t *testing.T) {
+	_, err := New(config.LoggingConfig{Level: "VERBOSE", Format: "text"})
+	assert.Error(t, err, "invalid level should be rejected")
+}
+
+func TestNewInvalidFormat(t *testing.T) {
+	_, err := New(config.LoggingConfig{Level: "INFO", Format: "xml"})
+	assert.Error(t, err, "invalid format should be rejected")
+}
+
+func TestWithRequestIDAndSessionID(t *testing.T) {
+	logger, err := New(config.LoggingConfig{Level: "INFO", Format: "json"})
+	assert.NoError(t, err)
+
+	assert.NotNil(t, WithRequestID(logger, "req-1"))
+	assert.NotNil(t, WithSessionID(logger, "sess-1"))
+}
@@ -0,0 +1,68 @@
+// Package output renders CLI command results in the json, yaml, or text
+// formats shared by every linuxwave-cli subcommand.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is one of the output formats accepted by --output.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+	FormatText Format = "text"
+)
+
+// TextFunc renders v as human-readable text to w. Commands provide one so
+// that --output=text produces friendlier output than a raw struct dump.
+type TextFunc func(w io.Writer, v interface{}) error
+
+// ParseFormat validates a --output flag value.
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case FormatJSON:
+		return FormatJSON, nil
+	case FormatYAML:
+		return FormatYAML, nil
+	case FormatText:
+		return FormatText, nil
+	default:
+		return "", fmt.Errorf("invalid output format %q: must be json, yaml, or text", s)
+	}
+}
+
+// Write renders v to w in the requested format. textFn is used for
+// FormatText; if it is nil, text output falls back to a "%+v" dump.
+func Write(w io.Writer, format Format, v interface{}, textFn TextFunc) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(v); err != nil {
+			return fmt.Errorf("failed to render JSON output: %w", err)
+		}
+		return nil
+	case FormatYAML:
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to render YAML output: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+	case FormatText, "":
+		if textFn != nil {
+			return textFn(w, v)
+		}
+		_, err := fmt.Fprintf(w, "%+v\n", v)
+		return err
+	default:
+		return fmt.Errorf("invalid output format %q: must be json, yaml, or text", format)
+	}
+}
@@ -0,0 +1,132 @@
+//go:build !nosandbox
+
+// Package sandbox drops the linuxwave service down to the minimum Linux
+// capabilities and syscalls it needs before it handles any camera or PAM
+// traffic. Build with the nosandbox tag to skip this in developer
+// environments that lack libseccomp or the privilege to call prctl. See
+// Apply's doc comment for a known gap: capabilities are only guaranteed
+// dropped on the thread that calls Apply, not on OS threads the Go
+// runtime started before it.
+package sandbox
+
+import (
+	"fmt"
+	"runtime"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/vinisadev/linuxwave/internal/config"
+)
+
+// lastCapability is the highest capability number defined by Linux's
+// linux/capability.h as of the 6.x series (CAP_CHECKPOINT_RESTORE).
+// Capabilities a newer kernel adds above this are left alone, but they
+// default to absent from a fresh bounding set regardless.
+const lastCapability = 40
+
+// keepCapabilities lists the only capabilities the service retains.
+// Camera (V4L2) capture and socket/file I/O under /run/linux-wave and the
+// user's enrollment directory need none of the traditionally privileged
+// capabilities; CAP_NET_BIND_SERVICE is kept only in case a future release
+// listens on a privileged TCP port instead of the current Unix socket.
+var keepCapabilities = map[int]bool{
+	unix.CAP_NET_BIND_SERVICE: true,
+}
+
+// Apply drops every Linux capability the service does not need, sets
+// PR_SET_NO_NEW_PRIVS, and installs a seccomp-bpf syscall allowlist. Call
+// it early in main, after any privileged setup (binding the control
+// socket, opening the camera device) and before handling any untrusted
+// input. A caller that gets an error back must treat it as fatal: a
+// service that kept running with excess privilege after a failed Apply
+// would defeat the point of calling it.
+//
+// Known limitation: PR_CAPBSET_DROP and capset(2) are per-thread, and the
+// Go runtime has already started its own background OS threads (sysmon,
+// GC workers) before any user code — including the first line of main —
+// runs, so those pre-existing threads keep the process's original
+// capabilities; only the thread that calls Apply, and any thread cloned
+// from it afterwards, are covered. Closing that gap needs capabilities
+// dropped before the Go runtime spawns a single thread (e.g. a re-exec
+// trampoline or a cgo constructor ahead of runtime init), which is
+// out of scope here.
+func Apply(cfg config.SecurityConfig) error {
+	// Pin this goroutine to its OS thread for the rest of the process's
+	// life: PR_CAPBSET_DROP and capset(2) below only ever affect the
+	// calling thread, so if the goroutine migrated mid-sequence the drop
+	// would be split across two threads, and the seccomp filter would be
+	// loaded on a third one entirely.
+	runtime.LockOSThread()
+
+	if err := dropCapabilities(); err != nil {
+		return fmt.Errorf("failed to drop capabilities: %w", err)
+	}
+
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return fmt.Errorf("failed to set no_new_privs: %w", err)
+	}
+
+	if err := installSeccompFilter(); err != nil {
+		return fmt.Errorf("failed to install seccomp filter: %w", err)
+	}
+
+	return nil
+}
+
+// dropCapabilities removes every capability not in keepCapabilities from
+// the calling thread's bounding set, which prevents it from ever being
+// re-acquired via a later setuid/setcap binary, and then clears the
+// same capabilities from the permitted, effective, inheritable, and
+// ambient sets so a service that started as root actually loses them
+// for the remainder of this run, not just for future exec(2)s.
+func dropCapabilities() error {
+	for capability := 0; capability <= lastCapability; capability++ {
+		if keepCapabilities[capability] {
+			continue
+		}
+		if err := unix.Prctl(unix.PR_CAPBSET_DROP, uintptr(capability), 0, 0, 0); err != nil {
+			if err == unix.EINVAL {
+				// The kernel doesn't know this capability number or it was
+				// already dropped; either way there's nothing left to do.
+				continue
+			}
+			return fmt.Errorf("capability %d: %w", capability, err)
+		}
+	}
+
+	return clearRunningCapabilitySets()
+}
+
+// clearRunningCapabilitySets drops every capability not in
+// keepCapabilities from the *calling thread's* permitted, effective, and
+// inheritable sets via capset(2), then clears its ambient set.
+// PR_CAPBSET_DROP alone only shrinks the bounding set, a ceiling on what
+// the thread could regain later; it leaves whatever the thread already
+// holds untouched, so a daemon started as root would keep every
+// capability in its effective set despite a "successful" Apply. See
+// Apply's doc comment for the resulting gap on threads that existed
+// before Apply ran.
+func clearRunningCapabilitySets() error {
+	hdr := unix.CapUserHeader{
+		Version: unix.LINUX_CAPABILITY_VERSION_3,
+		Pid:     0, // the calling thread
+	}
+
+	var data [2]unix.CapUserData
+	for capability := range keepCapabilities {
+		word, bit := capability/32, uint(capability%32)
+		data[word].Effective |= 1 << bit
+		data[word].Permitted |= 1 << bit
+		data[word].Inheritable |= 1 << bit
+	}
+
+	if err := unix.Capset(&hdr, &data[0]); err != nil {
+		return fmt.Errorf("capset: %w", err)
+	}
+
+	if err := unix.Prctl(unix.PR_CAP_AMBIENT, unix.PR_CAP_AMBIENT_CLEAR_ALL, 0, 0, 0); err != nil {
+		return fmt.Errorf("clear ambient capabilities: %w", err)
+	}
+
+	return nil
+}
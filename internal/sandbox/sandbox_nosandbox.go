@@ -0,0 +1,11 @@
+//go:build nosandbox
+
+package sandbox
+
+import "github.com/vinisadev/linuxwave/internal/config"
+
+// Apply is a no-op under the nosandbox build tag, for developer
+// environments that lack libseccomp or the privilege to call prctl.
+func Apply(cfg config.SecurityConfig) error {
+	return nil
+}
@@ -0,0 +1,62 @@
+//go:build !nosandbox
+
+package sandbox
+
+import (
+	"fmt"
+
+	seccomp "github.com/seccomp/libseccomp-golang"
+	"golang.org/x/sys/unix"
+)
+
+// allowedSyscalls is the minimal set the service needs: V4L2 camera
+// capture (ioctl-driven), Unix domain socket I/O for the control socket,
+// and file access under /run/linux-wave and the user's enrollment
+// directory, plus what the Go runtime and glibc use to do any of that.
+// Anything else is rejected with EPERM. Go's os package never issues the
+// legacy open(2)/unlink(2)/rename(2)/mkdir(2) forms on Linux — it always
+// goes through the *at variants (os.Open->openat, os.Remove->unlinkat,
+// os.Rename->renameat2, os.Mkdir->mkdirat) — so list those instead, along
+// with fsync/fdatasync/ftruncate for the atomic config write path.
+var allowedSyscalls = []string{
+	"read", "write", "close", "openat", "fstat", "newfstatat", "statx",
+	"lseek", "getdents64",
+	"mmap", "munmap", "mprotect", "madvise", "brk", "ioctl",
+	"socket", "connect", "accept4", "bind", "listen", "sendto", "recvfrom", "shutdown",
+	"epoll_create1", "epoll_ctl", "epoll_wait", "epoll_pwait", "poll", "select",
+	"futex", "clone", "exit", "exit_group", "sched_yield",
+	"rt_sigaction", "rt_sigprocmask", "rt_sigreturn", "sigaltstack", "tgkill",
+	"nanosleep", "clock_nanosleep", "restart_syscall",
+	"getrandom", "unlinkat", "renameat", "renameat2", "mkdirat", "fcntl",
+	"fsync", "fdatasync", "ftruncate",
+	"getpid", "gettid",
+}
+
+// installSeccompFilter loads a seccomp-bpf filter that allows only
+// allowedSyscalls and returns EPERM for everything else.
+func installSeccompFilter() error {
+	filter, err := seccomp.NewFilter(seccomp.ActErrno.SetReturnCode(int16(unix.EPERM)))
+	if err != nil {
+		return fmt.Errorf("failed to create seccomp filter: %w", err)
+	}
+	defer filter.Release()
+
+	for _, name := range allowedSyscalls {
+		syscallID, err := seccomp.GetSyscallFromName(name)
+		if err != nil {
+			// Not every syscall name exists on every architecture (e.g.
+			// renameat2 predates some older kernels); skip what this
+			// kernel doesn't recognize.
+			continue
+		}
+		if err := filter.AddRule(syscallID, seccomp.ActAllow); err != nil {
+			return fmt.Errorf("failed to allow %s: %w", name, err)
+		}
+	}
+
+	if err := filter.Load(); err != nil {
+		return fmt.Errorf("failed to load seccomp filter into the kernel: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+//go:build !nosandbox
+
+package sandbox
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSeccompFilterAllowsSocketPath installs the real seccomp filter in a
+// child process, then drives the control-socket path (listen, dial,
+// write, read, cleanup) through it. A syscall missing from
+// allowedSyscalls surfaces here as EPERM/SIGSYS instead of only at
+// runtime in the service.
+func TestSeccompFilterAllowsSocketPath(t *testing.T) {
+	if os.Getenv("LINUXWAVE_SECCOMP_CHILD") == "1" {
+		runSeccompChild()
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=TestSeccompFilterAllowsSocketPath")
+	cmd.Env = append(os.Environ(), "LINUXWAVE_SECCOMP_CHILD=1")
+	out, err := cmd.CombinedOutput()
+	assert.NoError(t, err, "seccomp child failed:\n%s", out)
+}
+
+// runSeccompChild installs the seccomp filter and exercises the Unix
+// socket accept/read/write path plus the file access the control socket
+// and its temp directory need, exiting non-zero on the first failure so
+// the parent test can report it. It runs in a subprocess because
+// installing the filter restricts every syscall for the rest of the
+// process's life, which would otherwise break the test binary itself.
+func runSeccompChild() {
+	if err := installSeccompFilter(); err != nil {
+		childFatalf("install filter: %v", err)
+	}
+
+	dir, err := os.MkdirTemp("", "linuxwave-seccomp-*")
+	if err != nil {
+		childFatalf("mkdir temp: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	sockPath := dir + "/test.sock"
+	ln, err := net.Listen("unix", sockPath)
+	if err != nil {
+		childFatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		conn, err := ln.Accept()
+		if err != nil {
+			childFatalf("accept: %v", err)
+		}
+		defer conn.Close()
+		buf := make([]byte, len("hello"))
+		if _, err := conn.Read(buf); err != nil {
+			childFatalf("read: %v", err)
+		}
+	}()
+
+	conn, err := net.Dial("unix", sockPath)
+	if err != nil {
+		childFatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte("hello")); err != nil {
+		childFatalf("write: %v", err)
+	}
+	<-done
+}
+
+func childFatalf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}